@@ -2,6 +2,7 @@ package main
 
 import (
     "github.com/ckanner/redis-lock/lock"
+    "github.com/ckanner/redis-lock/lock/adapters/goredis"
     "github.com/go-redis/redis"
     "log"
 )
@@ -24,7 +25,7 @@ func main() {
     if err != nil {
         log.Fatalf("get a redis client fail, %s", err)
     }
-	distMutex := lock.NewDistributedMutex("key", "request-id", client, &lock.Options{})
+	distMutex := lock.NewDistributedMutex("key", "request-id", goredis.New(client), &lock.Options{})
 	err = distMutex.Lock()
 	if err != nil {
 	    log.Fatalf("get a lock fail, %s", err)
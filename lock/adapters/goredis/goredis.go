@@ -0,0 +1,94 @@
+// Package goredis adapts github.com/go-redis/redis clients to
+// lock.RedisClient. Client, ClusterClient and RingClient cover single-node
+// (and Sentinel-backed, since redis.NewFailoverClient also returns a
+// *redis.Client), Cluster, and Ring deployments respectively.
+package goredis
+
+import (
+	"context"
+	"time"
+
+	"github.com/ckanner/redis-lock/lock"
+	"github.com/go-redis/redis"
+)
+
+var (
+	_ lock.RedisClient = (*Client)(nil)
+	_ lock.RedisClient = (*ClusterClient)(nil)
+	_ lock.RedisClient = (*RingClient)(nil)
+)
+
+// Client adapts a *redis.Client to lock.RedisClient, binding every call to
+// the context it is given via redis.Client.WithContext.
+type Client struct {
+	client *redis.Client
+}
+
+// New wraps client so it satisfies lock.RedisClient.
+func New(client *redis.Client) *Client {
+	return &Client{client: client}
+}
+
+// SetNX implements lock.RedisClient.
+func (c *Client) SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error) {
+	return c.client.WithContext(ctx).SetNX(key, value, ttl).Result()
+}
+
+// Eval implements lock.RedisClient.
+func (c *Client) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	res, err := c.client.WithContext(ctx).Eval(script, keys, args...).Result()
+	if err == redis.Nil {
+		return res, lock.ErrNil
+	}
+	return res, err
+}
+
+// ClusterClient adapts a *redis.ClusterClient to lock.RedisClient, binding
+// every call to the context it is given via redis.ClusterClient.WithContext.
+type ClusterClient struct {
+	client *redis.ClusterClient
+}
+
+// NewCluster wraps client so it satisfies lock.RedisClient.
+func NewCluster(client *redis.ClusterClient) *ClusterClient {
+	return &ClusterClient{client: client}
+}
+
+// SetNX implements lock.RedisClient.
+func (c *ClusterClient) SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error) {
+	return c.client.WithContext(ctx).SetNX(key, value, ttl).Result()
+}
+
+// Eval implements lock.RedisClient.
+func (c *ClusterClient) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	res, err := c.client.WithContext(ctx).Eval(script, keys, args...).Result()
+	if err == redis.Nil {
+		return res, lock.ErrNil
+	}
+	return res, err
+}
+
+// RingClient adapts a *redis.Ring to lock.RedisClient, binding every call
+// to the context it is given via redis.Ring.WithContext.
+type RingClient struct {
+	client *redis.Ring
+}
+
+// NewRing wraps client so it satisfies lock.RedisClient.
+func NewRing(client *redis.Ring) *RingClient {
+	return &RingClient{client: client}
+}
+
+// SetNX implements lock.RedisClient.
+func (c *RingClient) SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error) {
+	return c.client.WithContext(ctx).SetNX(key, value, ttl).Result()
+}
+
+// Eval implements lock.RedisClient.
+func (c *RingClient) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	res, err := c.client.WithContext(ctx).Eval(script, keys, args...).Result()
+	if err == redis.Nil {
+		return res, lock.ErrNil
+	}
+	return res, err
+}
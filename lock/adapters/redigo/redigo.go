@@ -0,0 +1,62 @@
+// Package redigo adapts a github.com/gomodule/redigo/redis connection pool
+// to lock.RedisClient.
+package redigo
+
+import (
+	"context"
+	"time"
+
+	"github.com/ckanner/redis-lock/lock"
+	"github.com/gomodule/redigo/redis"
+)
+
+var _ lock.RedisClient = (*Client)(nil)
+
+// Client adapts a redigo pool to lock.RedisClient. A connection is
+// borrowed from the pool, bound to the call's context via Pool.GetContext,
+// and closed for every call.
+type Client struct {
+	Pool *redis.Pool
+}
+
+// New wraps pool so it satisfies lock.RedisClient.
+func New(pool *redis.Pool) *Client {
+	return &Client{Pool: pool}
+}
+
+// SetNX implements lock.RedisClient.
+func (c *Client) SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error) {
+	conn, err := c.Pool.GetContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	reply, err := redis.String(conn.Do("SET", key, value, "PX", ttl.Milliseconds(), "NX"))
+	if err == redis.ErrNil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return reply == "OK", nil
+}
+
+// Eval implements lock.RedisClient.
+func (c *Client) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	conn, err := c.Pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	cmdArgs := make([]interface{}, 0, len(keys)+len(args)+2)
+	cmdArgs = append(cmdArgs, script, len(keys))
+	for _, k := range keys {
+		cmdArgs = append(cmdArgs, k)
+	}
+	cmdArgs = append(cmdArgs, args...)
+	res, doErr := conn.Do("EVAL", cmdArgs...)
+	if doErr == nil && res == nil {
+		return nil, lock.ErrNil
+	}
+	return res, doErr
+}
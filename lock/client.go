@@ -0,0 +1,27 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNil is returned by RedisClient implementations in place of a
+// driver-specific "nil reply" error, so this package can stay independent
+// of any particular redis client.
+var ErrNil = errors.New("lock: redis: nil")
+
+// RedisClient is the minimal set of redis operations this package needs to
+// implement its locks. It lets DistributedMutex and RedMutex run against
+// anything that can satisfy it: a single node, Cluster, Ring, a
+// Sentinel-backed client, or a redigo connection/pool, via the adapters
+// under lock/adapters. Every call takes a context so callers can cancel a
+// request that is in flight, not just the wait between retries.
+type RedisClient interface {
+	// SetNX sets key to value with the given TTL, only if key does not
+	// already exist.
+	SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error)
+
+	// Eval runs a Lua script against keys and args.
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error)
+}
@@ -3,11 +3,10 @@
 package lock
 
 import (
+	"context"
 	"errors"
 	"sync"
 	"time"
-
-	"github.com/go-redis/redis"
 )
 
 var (
@@ -26,13 +25,19 @@ type DistributedMutex struct {
 	Value string
 
 	// A redis client.
-	Client *redis.Client
+	Client RedisClient
 
 	// A option of the lock.
 	Opt *Options
 
 	// A local lock.
 	Mutex sync.Mutex
+
+	// stopCh signals the watchdog goroutine to stop when AutoRefresh is enabled.
+	stopCh chan struct{}
+
+	// lostCh reports that the watchdog detected that the lock was lost.
+	lostCh chan error
 }
 
 // Options defines some options for the lock.
@@ -49,10 +54,28 @@ type Options struct {
 	// The duration of the next time to lock.
 	// Default: 100ms
 	RetryDelay time.Duration
+
+	// Factor is the clock drift factor used by RedMutex to compensate for
+	// communication delays between the client and the redis nodes.
+	// Default: 0.01
+	Factor float64
+
+	// AutoRefresh enables a background watchdog that periodically extends
+	// the key's TTL while the lock is held, so callers don't need to set
+	// Expiration to an overly large worst-case value.
+	// Default: false
+	AutoRefresh bool
+
+	// RefreshInterval is the interval at which the watchdog extends the TTL
+	// when AutoRefresh is enabled.
+	// Default: Expiration/3
+	RefreshInterval time.Duration
 }
 
-// NewDistributedMutex creates a distributed mutex.
-func NewDistributedMutex(key, value string, client *redis.Client, opt *Options) *DistributedMutex {
+// NewDistributedMutex creates a distributed mutex. client may be any
+// implementation of RedisClient, including the adapters shipped under
+// lock/adapters for go-redis and redigo.
+func NewDistributedMutex(key, value string, client RedisClient, opt *Options) *DistributedMutex {
 	opt.init()
 	return &DistributedMutex{
 		Key:    key,
@@ -64,35 +87,53 @@ func NewDistributedMutex(key, value string, client *redis.Client, opt *Options)
 
 // Lock try to acquire a lock.
 func (d *DistributedMutex) Lock() error {
+	return d.LockContext(context.Background())
+}
+
+// LockContext try to acquire a lock, like Lock, but the wait between retries
+// is aborted as soon as ctx is done.
+func (d *DistributedMutex) LockContext(ctx context.Context) error {
 	d.Mutex.Lock()
 	defer d.Mutex.Unlock()
 	retryCount := d.Opt.RetryCount
 	for {
-		ok, err := d.tryLock()
+		ok, err := d.tryLock(ctx)
 		if err != nil {
 			return err
 		} else if ok {
+			if d.Opt.AutoRefresh {
+				d.startWatchdog()
+			}
 			return nil
 		}
 		if retryCount <= 0 {
 			return ErrLockNotAcquired
 		}
-		time.Sleep(d.Opt.RetryDelay)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(d.Opt.RetryDelay):
+		}
 		retryCount--
 	}
 }
 
-func (d *DistributedMutex) tryLock() (bool, error) {
-	ok, err := d.Client.SetNX(d.Key, d.Value, d.Opt.Expiration).Result()
-	return ok, err
+func (d *DistributedMutex) tryLock(ctx context.Context) (bool, error) {
+	return d.Client.SetNX(ctx, d.Key, d.Value, d.Opt.Expiration)
 }
 
 // Unlock release the lock.
 func (d *DistributedMutex) Unlock() error {
+	return d.UnlockContext(context.Background())
+}
+
+// UnlockContext release the lock, like Unlock, but the request is bound to ctx.
+func (d *DistributedMutex) UnlockContext(ctx context.Context) error {
 	d.Mutex.Lock()
 	defer d.Mutex.Unlock()
-	res, err := d.Client.Eval(UnlockLuaScript, []string{d.Key}, d.Value).Result()
-	if err == redis.Nil {
+	d.stopWatchdog()
+	res, err := d.Client.Eval(ctx, UnlockLuaScript, []string{d.Key}, d.Value)
+	if err == ErrNil {
 		return ErrUnlockKeyExpired
 	}
 	if err != nil {
@@ -114,5 +155,11 @@ func (o *Options) init() *Options {
 	if o.RetryDelay < 1 {
 		o.RetryDelay = 100 * time.Millisecond
 	}
+	if o.Factor <= 0 {
+		o.Factor = 0.01
+	}
+	if o.RefreshInterval < 1 {
+		o.RefreshInterval = o.Expiration / 3
+	}
 	return o
 }
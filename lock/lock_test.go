@@ -0,0 +1,30 @@
+package lock
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDistributedMutexLockContextCancellation(t *testing.T) {
+	client := &fakeRedisClient{setNXOK: false}
+	m := NewDistributedMutex("key", "value", client, &Options{
+		Expiration: time.Second,
+		RetryCount: 1000,
+		RetryDelay: 50 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := m.LockContext(ctx)
+	elapsed := time.Since(start)
+
+	if err != context.DeadlineExceeded {
+		t.Fatalf("LockContext() err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed >= time.Duration(m.Opt.RetryCount)*m.Opt.RetryDelay {
+		t.Errorf("LockContext() took %v, want it to return as soon as ctx was cancelled, well before the retry budget was exhausted", elapsed)
+	}
+}
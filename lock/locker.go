@@ -0,0 +1,93 @@
+package lock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"sync"
+	"time"
+)
+
+// A Locker manages the distributed mutexes obtained through it, so call
+// sites no longer have to construct a NewDistributedMutex themselves and
+// come up with a globally-unique Value for every key.
+type Locker struct {
+	// A redis client shared by every mutex obtained through this locker.
+	Client RedisClient
+
+	// The default options used when Obtain is called without one.
+	DefaultOpt *Options
+
+	mutex sync.Mutex
+	locks map[string]*DistributedMutex // keyed by DistributedMutex.Value, not the redis key
+}
+
+// NewLocker creates a Locker.
+func NewLocker(client RedisClient, defaultOpt *Options) *Locker {
+	return &Locker{
+		Client:     client,
+		DefaultOpt: defaultOpt,
+		locks:      make(map[string]*DistributedMutex),
+	}
+}
+
+// Obtain acquires a lock on key with a random, process-unique Value and
+// tracks it so it can later be released through ReleaseAll. If opt is nil,
+// the Locker's DefaultOpt is used.
+func (l *Locker) Obtain(ctx context.Context, key string, ttl time.Duration, opt *Options) (*DistributedMutex, error) {
+	if opt == nil {
+		opt = l.DefaultOpt
+	}
+	var o Options
+	if opt != nil {
+		o = *opt
+	}
+	opt = &o
+	opt.Expiration = ttl
+
+	value, err := randomValue()
+	if err != nil {
+		return nil, err
+	}
+
+	m := NewDistributedMutex(key, value, l.Client, opt)
+	if err := m.LockContext(ctx); err != nil {
+		return nil, err
+	}
+
+	// Track the mutex by its own random value rather than key, since two
+	// mutexes obtained for the same key (e.g. an earlier caller that forgot
+	// to Unlock) must both stay reachable from ReleaseAll.
+	l.mutex.Lock()
+	l.locks[value] = m
+	l.mutex.Unlock()
+	return m, nil
+}
+
+// ReleaseAll unlocks every mutex currently tracked by the Locker. It is
+// meant to be called on graceful shutdown. The first error encountered is
+// returned, but every tracked mutex is given a chance to unlock.
+func (l *Locker) ReleaseAll(ctx context.Context) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	var firstErr error
+	for handle, m := range l.locks {
+		if err := m.UnlockContext(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(l.locks, handle)
+	}
+	return firstErr
+}
+
+// randomValue generates a cryptographically random, base64-encoded value
+// suitable for use as a mutex's Value, so that it can't accidentally be
+// reused across processes.
+func randomValue() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
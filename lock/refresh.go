@@ -0,0 +1,69 @@
+package lock
+
+import (
+	"context"
+	"time"
+)
+
+// RefreshLuaScript extends the key's TTL only if it is still owned by this value.
+var RefreshLuaScript = "if redis.call('get', KEYS[1]) == ARGV[1] then return redis.call('pexpire', KEYS[1], ARGV[2]) else return 0 end"
+
+// Refresh extends the TTL of the key back to Opt.Expiration, as long as the
+// key is still owned by this mutex's Value. It returns ErrUnlockKeyExpired
+// if the lock was lost.
+func (d *DistributedMutex) Refresh() error {
+	res, err := d.Client.Eval(context.Background(), RefreshLuaScript, []string{d.Key}, d.Value, d.Opt.Expiration.Milliseconds())
+	if err != nil {
+		return err
+	}
+	if n, ok := res.(int64); ok && n == 1 {
+		return nil
+	}
+	return ErrUnlockKeyExpired
+}
+
+// LostCh returns a channel that receives an error if the watchdog detects
+// that the lock was lost while AutoRefresh is enabled. It is nil until a Lock
+// with Opt.AutoRefresh enabled succeeds; after that it is the channel of the
+// most recent such Lock, and it stays in place after Unlock or a lost lock.
+func (d *DistributedMutex) LostCh() <-chan error {
+	return d.lostCh
+}
+
+// startWatchdog spawns a goroutine that periodically calls Refresh until
+// stopWatchdog is called or the lock is detected as lost.
+func (d *DistributedMutex) startWatchdog() {
+	d.stopCh = make(chan struct{})
+	d.lostCh = make(chan error, 1)
+	stopCh, lostCh := d.stopCh, d.lostCh
+	go func() {
+		ticker := time.NewTicker(d.Opt.RefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				err := d.Refresh()
+				if err == nil {
+					continue
+				}
+				if err == ErrUnlockKeyExpired {
+					lostCh <- err
+					return
+				}
+				// A transient error (network blip, timeout) does not mean
+				// the lock was lost: keep the ticker running and retry on
+				// the next tick.
+			}
+		}
+	}()
+}
+
+// stopWatchdog stops the watchdog goroutine started by startWatchdog, if any.
+func (d *DistributedMutex) stopWatchdog() {
+	if d.stopCh != nil {
+		close(d.stopCh)
+		d.stopCh = nil
+	}
+}
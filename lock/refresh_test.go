@@ -0,0 +1,92 @@
+package lock
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newAutoRefreshMutex(client *fakeRedisClient, refreshInterval time.Duration) *DistributedMutex {
+	return NewDistributedMutex("key", "value", client, &Options{
+		Expiration:      time.Second,
+		AutoRefresh:     true,
+		RefreshInterval: refreshInterval,
+	})
+}
+
+func TestDistributedMutexWatchdogStopsOnUnlock(t *testing.T) {
+	client := &fakeRedisClient{setNXOK: true}
+	m := newAutoRefreshMutex(client, 10*time.Millisecond)
+
+	if err := m.Lock(); err != nil {
+		t.Fatalf("Lock() err = %v, want nil", err)
+	}
+	time.Sleep(35 * time.Millisecond)
+	if atomic.LoadInt32(&client.evalled) == 0 {
+		t.Fatalf("expected the watchdog to have refreshed at least once before Unlock")
+	}
+
+	if err := m.Unlock(); err != nil {
+		t.Fatalf("Unlock() err = %v, want nil", err)
+	}
+	afterUnlock := atomic.LoadInt32(&client.evalled)
+
+	time.Sleep(35 * time.Millisecond)
+	if got := atomic.LoadInt32(&client.evalled); got != afterUnlock {
+		t.Errorf("Eval called %d more times after Unlock, want 0 (watchdog should have stopped)", got-afterUnlock)
+	}
+}
+
+func TestDistributedMutexWatchdogSignalsLostOnCASMiss(t *testing.T) {
+	client := &fakeRedisClient{
+		setNXOK: true,
+		evalFn: func(script string, keys []string, args ...interface{}) (interface{}, error) {
+			// Simulate another client having taken over the key: the CAS
+			// check in RefreshLuaScript fails, so it returns 0.
+			return int64(0), nil
+		},
+	}
+	m := newAutoRefreshMutex(client, 10*time.Millisecond)
+
+	if err := m.Lock(); err != nil {
+		t.Fatalf("Lock() err = %v, want nil", err)
+	}
+
+	select {
+	case err := <-m.LostCh():
+		if err != ErrUnlockKeyExpired {
+			t.Errorf("LostCh() err = %v, want %v", err, ErrUnlockKeyExpired)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("timed out waiting for LostCh to signal the lost lock")
+	}
+}
+
+func TestDistributedMutexWatchdogSurvivesTransientRefreshError(t *testing.T) {
+	var calls int32
+	client := &fakeRedisClient{
+		setNXOK: true,
+		evalFn: func(script string, keys []string, args ...interface{}) (interface{}, error) {
+			if atomic.AddInt32(&calls, 1) <= 2 {
+				return nil, errors.New("connection reset by peer")
+			}
+			return int64(1), nil
+		},
+	}
+	m := newAutoRefreshMutex(client, 10*time.Millisecond)
+
+	if err := m.Lock(); err != nil {
+		t.Fatalf("Lock() err = %v, want nil", err)
+	}
+
+	select {
+	case err := <-m.LostCh():
+		t.Fatalf("LostCh() unexpectedly signalled %v on a transient error", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if err := m.Unlock(); err != nil {
+		t.Fatalf("Unlock() err = %v, want nil", err)
+	}
+}
@@ -0,0 +1,33 @@
+package lock
+
+import (
+	"context"
+	"time"
+)
+
+// TTLLuaScript returns the remaining PTTL of the key only if it is still owned by this value.
+var TTLLuaScript = "if redis.call('get', KEYS[1]) == ARGV[1] then return redis.call('pttl', KEYS[1]) else return -1 end"
+
+// TTL returns the remaining time the lock is valid for. It returns zero,
+// without error, if the lock is no longer held by this mutex, so callers
+// can decide whether a slow operation still has enough time to commit.
+func (d *DistributedMutex) TTL(ctx context.Context) (time.Duration, error) {
+	res, err := d.Client.Eval(ctx, TTLLuaScript, []string{d.Key}, d.Value)
+	if err != nil {
+		return 0, err
+	}
+	ms, ok := res.(int64)
+	if !ok || ms < 0 {
+		return 0, nil
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// IsHeld reports whether this mutex still owns the lock.
+func (d *DistributedMutex) IsHeld(ctx context.Context) (bool, error) {
+	ttl, err := d.TTL(ctx)
+	if err != nil {
+		return false, err
+	}
+	return ttl > 0, nil
+}
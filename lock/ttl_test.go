@@ -0,0 +1,46 @@
+package lock
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDistributedMutexTTLAndIsHeld(t *testing.T) {
+	tests := []struct {
+		name     string
+		pttl     int64
+		wantTTL  time.Duration
+		wantHeld bool
+	}{
+		{name: "held", pttl: 1500, wantTTL: 1500 * time.Millisecond, wantHeld: true},
+		{name: "lost", pttl: -1, wantTTL: 0, wantHeld: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeRedisClient{
+				evalFn: func(script string, keys []string, args ...interface{}) (interface{}, error) {
+					return tt.pttl, nil
+				},
+			}
+			m := NewDistributedMutex("key", "value", client, &Options{})
+
+			ttl, err := m.TTL(context.Background())
+			if err != nil {
+				t.Fatalf("TTL() err = %v, want nil", err)
+			}
+			if ttl != tt.wantTTL {
+				t.Errorf("TTL() = %v, want %v", ttl, tt.wantTTL)
+			}
+
+			held, err := m.IsHeld(context.Background())
+			if err != nil {
+				t.Fatalf("IsHeld() err = %v, want nil", err)
+			}
+			if held != tt.wantHeld {
+				t.Errorf("IsHeld() = %v, want %v", held, tt.wantHeld)
+			}
+		})
+	}
+}